@@ -0,0 +1,49 @@
+package midi
+
+// NewProgramChangeEvent builds a Program Change event, selecting program on
+// channel ch.
+func NewProgramChangeEvent(ch, program uint8, deltaTime uint32) *Event {
+	return &Event{
+		TimeDelta:  deltaTime,
+		MsgType:    0xC,
+		MsgChan:    ch,
+		NewProgram: program,
+	}
+}
+
+// NewChannelPressureEvent builds a Channel Pressure (Aftertouch) event,
+// reporting the single greatest pressure value across the keys currently
+// held on channel ch.
+func NewChannelPressureEvent(ch, pressure uint8, deltaTime uint32) *Event {
+	return &Event{
+		TimeDelta: deltaTime,
+		MsgType:   0xD,
+		MsgChan:   ch,
+		Pressure:  pressure,
+	}
+}
+
+// NewPitchBendEvent builds a Pitch Bend Change event on channel ch. bend is
+// the signed offset from center, in [-8192, 8191]; it's packed into the
+// 14-bit wire value as bend + 0x2000.
+func NewPitchBendEvent(ch uint8, bend int16, deltaTime uint32) *Event {
+	return &Event{
+		TimeDelta:    deltaTime,
+		MsgType:      0xE,
+		MsgChan:      ch,
+		RelPitchBend: bend,
+		AbsPitchBend: uint16(int32(bend) + 0x2000),
+	}
+}
+
+// NewPolyAftertouchEvent builds a Polyphonic Key Pressure (Aftertouch)
+// event, reporting pressure for a single held note on channel ch.
+func NewPolyAftertouchEvent(ch, note, pressure uint8, deltaTime uint32) *Event {
+	return &Event{
+		TimeDelta: deltaTime,
+		MsgType:   0xA,
+		MsgChan:   ch,
+		Note:      note,
+		Velocity:  pressure,
+	}
+}
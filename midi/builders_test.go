@@ -0,0 +1,50 @@
+package midi
+
+import "testing"
+
+func TestNewProgramChangeEventEncodesOneDataByte(t *testing.T) {
+	e := NewProgramChangeEvent(2, 0x28, 0)
+	got := e.Encode()
+	want := []byte{0x0, 0xC2, 0x28}
+	assertBytesEqual(t, got, want)
+	if e.Size() != 1 {
+		t.Fatalf("Size() = %d, want 1", e.Size())
+	}
+}
+
+func TestNewChannelPressureEventEncodesOneDataByte(t *testing.T) {
+	e := NewChannelPressureEvent(1, 0x50, 0)
+	got := e.Encode()
+	want := []byte{0x0, 0xD1, 0x50}
+	assertBytesEqual(t, got, want)
+	if e.Size() != 1 {
+		t.Fatalf("Size() = %d, want 1", e.Size())
+	}
+}
+
+func TestNewPitchBendEventCenterAndExtremes(t *testing.T) {
+	center := NewPitchBendEvent(0, 0, 0)
+	got := center.Encode()
+	want := []byte{0x0, 0xE0, 0x00, 0x40} // 0x2000 -> lsb 0x00, msb 0x40
+	assertBytesEqual(t, got, want)
+
+	min := NewPitchBendEvent(0, -8192, 0)
+	got = min.Encode()
+	want = []byte{0x0, 0xE0, 0x00, 0x00}
+	assertBytesEqual(t, got, want)
+
+	max := NewPitchBendEvent(0, 8191, 0)
+	got = max.Encode()
+	want = []byte{0x0, 0xE0, 0x7F, 0x7F}
+	assertBytesEqual(t, got, want)
+}
+
+func TestNewPolyAftertouchEventEncodesTwoDataBytes(t *testing.T) {
+	e := NewPolyAftertouchEvent(3, 0x3C, 0x40, 0)
+	got := e.Encode()
+	want := []byte{0x0, 0xA3, 0x3C, 0x40}
+	assertBytesEqual(t, got, want)
+	if e.Size() != 2 {
+		t.Fatalf("Size() = %d, want 2", e.Size())
+	}
+}
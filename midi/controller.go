@@ -0,0 +1,190 @@
+package midi
+
+import "fmt"
+
+// Controller identifies a Control Change controller number (0-119), or one
+// of the reserved Channel Mode messages (120-127).
+type Controller uint8
+
+// Standard Control Change controller numbers.
+// See http://www.midi.org/techspecs/midimessages.php
+const (
+	BankSelectMSB    Controller = 0
+	ModulationWheel  Controller = 1
+	BreathController Controller = 2
+	FootController   Controller = 4
+	PortamentoTime   Controller = 5
+	DataEntryMSB     Controller = 6
+	ChannelVolume    Controller = 7
+	Balance          Controller = 8
+	Pan              Controller = 10
+	Expression       Controller = 11
+	EffectControl1   Controller = 12
+	EffectControl2   Controller = 13
+	GeneralPurpose1  Controller = 16
+	GeneralPurpose2  Controller = 17
+	GeneralPurpose3  Controller = 18
+	GeneralPurpose4  Controller = 19
+	BankSelectLSB    Controller = 32
+	DataEntryLSB     Controller = 38
+
+	SustainPedal      Controller = 64
+	Portamento        Controller = 65 // Portamento On/Off
+	Sostenuto         Controller = 66
+	SoftPedal         Controller = 67
+	Legato            Controller = 68
+	Hold2             Controller = 69
+	SoundController1  Controller = 70 // Sound Variation
+	SoundController2  Controller = 71 // Timbre/Harmonic Content
+	SoundController3  Controller = 72 // Release Time
+	SoundController4  Controller = 73 // Attack Time
+	SoundController5  Controller = 74 // Brightness
+	SoundController6  Controller = 75
+	SoundController7  Controller = 76
+	SoundController8  Controller = 77
+	SoundController9  Controller = 78
+	SoundController10 Controller = 79
+	PortamentoControl Controller = 84
+	Effects1Depth     Controller = 91 // Reverb
+	Effects2Depth     Controller = 92 // Tremolo
+	Effects3Depth     Controller = 93 // Chorus
+	Effects4Depth     Controller = 94 // Celeste/Detune
+	Effects5Depth     Controller = 95 // Phaser
+	DataIncrement     Controller = 96
+	DataDecrement     Controller = 97
+	NRPNLSB           Controller = 98
+	NRPNMSB           Controller = 99
+	RPNLSB            Controller = 100
+	RPNMSB            Controller = 101
+
+	// Controller numbers 120-127 are reserved as Channel Mode messages.
+	AllSoundOff         Controller = 120
+	ResetAllControllers Controller = 121
+	LocalControl        Controller = 122
+	AllNotesOff         Controller = 123
+	OmniOff             Controller = 124
+	OmniOn              Controller = 125
+	MonoOn              Controller = 126
+	PolyOn              Controller = 127
+)
+
+var controllerNames = map[Controller]string{
+	BankSelectMSB:       "Bank Select (MSB)",
+	ModulationWheel:     "Modulation Wheel",
+	BreathController:    "Breath Controller",
+	FootController:      "Foot Controller",
+	PortamentoTime:      "Portamento Time",
+	DataEntryMSB:        "Data Entry (MSB)",
+	ChannelVolume:       "Channel Volume",
+	Balance:             "Balance",
+	Pan:                 "Pan",
+	Expression:          "Expression Controller",
+	EffectControl1:      "Effect Control 1",
+	EffectControl2:      "Effect Control 2",
+	GeneralPurpose1:     "General Purpose Controller 1",
+	GeneralPurpose2:     "General Purpose Controller 2",
+	GeneralPurpose3:     "General Purpose Controller 3",
+	GeneralPurpose4:     "General Purpose Controller 4",
+	BankSelectLSB:       "Bank Select (LSB)",
+	DataEntryLSB:        "Data Entry (LSB)",
+	SustainPedal:        "Sustain Pedal",
+	Portamento:          "Portamento On/Off",
+	Sostenuto:           "Sostenuto",
+	SoftPedal:           "Soft Pedal",
+	Legato:              "Legato Footswitch",
+	Hold2:               "Hold 2",
+	SoundController1:    "Sound Controller 1 (Sound Variation)",
+	SoundController2:    "Sound Controller 2 (Timbre)",
+	SoundController3:    "Sound Controller 3 (Release Time)",
+	SoundController4:    "Sound Controller 4 (Attack Time)",
+	SoundController5:    "Sound Controller 5 (Brightness)",
+	SoundController6:    "Sound Controller 6",
+	SoundController7:    "Sound Controller 7",
+	SoundController8:    "Sound Controller 8",
+	SoundController9:    "Sound Controller 9",
+	SoundController10:   "Sound Controller 10",
+	PortamentoControl:   "Portamento Control",
+	Effects1Depth:       "Effects 1 Depth (Reverb)",
+	Effects2Depth:       "Effects 2 Depth (Tremolo)",
+	Effects3Depth:       "Effects 3 Depth (Chorus)",
+	Effects4Depth:       "Effects 4 Depth (Celeste)",
+	Effects5Depth:       "Effects 5 Depth (Phaser)",
+	DataIncrement:       "Data Increment",
+	DataDecrement:       "Data Decrement",
+	NRPNLSB:             "NRPN (LSB)",
+	NRPNMSB:             "NRPN (MSB)",
+	RPNLSB:              "RPN (LSB)",
+	RPNMSB:              "RPN (MSB)",
+	AllSoundOff:         "All Sound Off",
+	ResetAllControllers: "Reset All Controllers",
+	LocalControl:        "Local Control",
+	AllNotesOff:         "All Notes Off",
+	OmniOff:             "Omni Mode Off",
+	OmniOn:              "Omni Mode On",
+	MonoOn:              "Mono Mode On (Poly Off)",
+	PolyOn:              "Poly Mode On (Mono Off)",
+}
+
+// ControllerName returns the standard name for a Control Change controller
+// number, or a generic placeholder if c isn't one of the assigned numbers.
+func ControllerName(c byte) string {
+	if name, ok := controllerNames[Controller(c)]; ok {
+		return name
+	}
+	return fmt.Sprintf("Controller %d", c)
+}
+
+// NewControlChangeEvent builds a Control Change event on channel ch.
+func NewControlChangeEvent(ch uint8, controller Controller, value uint8, deltaTime uint32) *Event {
+	return &Event{
+		TimeDelta:  deltaTime,
+		MsgType:    0xB,
+		MsgChan:    ch,
+		Controller: uint8(controller),
+		NewValue:   value,
+	}
+}
+
+// NewAllNotesOffEvent builds the Channel Mode message that silences every
+// currently sounding note on channel ch.
+func NewAllNotesOffEvent(ch uint8, deltaTime uint32) *Event {
+	return NewControlChangeEvent(ch, AllNotesOff, 0, deltaTime)
+}
+
+// NewLocalControlEvent builds the Channel Mode message that switches a
+// device's local keyboard-to-sound-engine connection on or off.
+func NewLocalControlEvent(ch uint8, on bool, deltaTime uint32) *Event {
+	v := uint8(0)
+	if on {
+		v = 127
+	}
+	return NewControlChangeEvent(ch, LocalControl, v, deltaTime)
+}
+
+// NewResetAllControllersEvent builds the Channel Mode message that resets
+// every controller on channel ch to its default value.
+func NewResetAllControllersEvent(ch uint8, deltaTime uint32) *Event {
+	return NewControlChangeEvent(ch, ResetAllControllers, 0, deltaTime)
+}
+
+// NewNRPNEvents builds the standard four Control Change sequence that
+// selects Non-Registered Parameter param (14-bit) and sets it to value
+// (14-bit): NRPN MSB/LSB (CC 99/98) followed by Data Entry MSB/LSB (CC 6/38).
+func NewNRPNEvents(ch uint8, param, value uint16, deltaTime uint32) []*Event {
+	return rpnSequence(ch, NRPNMSB, NRPNLSB, param, value, deltaTime)
+}
+
+// NewRPNEvents is the Registered Parameter equivalent of NewNRPNEvents,
+// using RPN MSB/LSB (CC 101/100) to select the parameter.
+func NewRPNEvents(ch uint8, param, value uint16, deltaTime uint32) []*Event {
+	return rpnSequence(ch, RPNMSB, RPNLSB, param, value, deltaTime)
+}
+
+func rpnSequence(ch uint8, msbSelect, lsbSelect Controller, param, value uint16, deltaTime uint32) []*Event {
+	return []*Event{
+		NewControlChangeEvent(ch, msbSelect, uint8(param>>7)&0x7F, deltaTime),
+		NewControlChangeEvent(ch, lsbSelect, uint8(param)&0x7F, 0),
+		NewControlChangeEvent(ch, DataEntryMSB, uint8(value>>7)&0x7F, 0),
+		NewControlChangeEvent(ch, DataEntryLSB, uint8(value)&0x7F, 0),
+	}
+}
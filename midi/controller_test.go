@@ -0,0 +1,67 @@
+package midi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestControllerName(t *testing.T) {
+	if got, want := ControllerName(byte(SustainPedal)), "Sustain Pedal"; got != want {
+		t.Fatalf("ControllerName(SustainPedal) = %q, want %q", got, want)
+	}
+	if got, want := ControllerName(byte(AllNotesOff)), "All Notes Off"; got != want {
+		t.Fatalf("ControllerName(AllNotesOff) = %q, want %q", got, want)
+	}
+	if got := ControllerName(3); got != "Controller 3" {
+		t.Fatalf("ControllerName(3) = %q, want fallback name", got)
+	}
+}
+
+func TestNewControlChangeEvent(t *testing.T) {
+	e := NewControlChangeEvent(5, SustainPedal, 127, 10)
+	got := e.Encode()
+	want := []byte{0xA, 0xB5, 0x40, 0x7F}
+	assertBytesEqual(t, got, want)
+}
+
+func TestNewAllNotesOffEvent(t *testing.T) {
+	e := NewAllNotesOffEvent(0, 0)
+	got := e.Encode()
+	want := []byte{0x0, 0xB0, 0x7B, 0x0}
+	assertBytesEqual(t, got, want)
+}
+
+func TestNewLocalControlEvent(t *testing.T) {
+	on := NewLocalControlEvent(0, true, 0)
+	assertBytesEqual(t, on.Encode(), []byte{0x0, 0xB0, 0x7A, 0x7F})
+
+	off := NewLocalControlEvent(0, false, 0)
+	assertBytesEqual(t, off.Encode(), []byte{0x0, 0xB0, 0x7A, 0x0})
+}
+
+func TestNewNRPNEvents(t *testing.T) {
+	events := NewNRPNEvents(0, 0x0102, 0x0304, 5)
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+	wantControllers := []byte{byte(NRPNMSB), byte(NRPNLSB), byte(DataEntryMSB), byte(DataEntryLSB)}
+	wantValues := []byte{0x02, 0x02, 0x06, 0x04}
+	for i, ev := range events {
+		if ev.Controller != wantControllers[i] {
+			t.Fatalf("event %d controller = %#x, want %#x", i, ev.Controller, wantControllers[i])
+		}
+		if ev.NewValue != wantValues[i] {
+			t.Fatalf("event %d value = %#x, want %#x", i, ev.NewValue, wantValues[i])
+		}
+	}
+	if events[0].TimeDelta != 5 || events[1].TimeDelta != 0 {
+		t.Fatalf("only the first event in the sequence should carry the delta-time")
+	}
+}
+
+func TestEventStringIncludesControllerName(t *testing.T) {
+	e := NewControlChangeEvent(0, SustainPedal, 127, 0)
+	if got := e.String(); !strings.Contains(got, "Sustain Pedal") {
+		t.Fatalf("String() = %q, want it to contain the controller name %q", got, "Sustain Pedal")
+	}
+}
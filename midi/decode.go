@@ -0,0 +1,108 @@
+package midi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// DecodeEvent parses a single meta-event (delta-time, 0xFF status byte, cmd,
+// varint length, payload) from the start of b, returning the decoded Event
+// and the number of bytes consumed. It's the inverse of Encode for meta
+// events; it doesn't yet decode channel-voice or System events.
+func DecodeEvent(b []byte) (*Event, int, error) {
+	deltaTime, n := DecodeVarint(b)
+	if n == 0 || n >= len(b) {
+		return nil, 0, errors.New("midi: truncated event")
+	}
+	if b[n] != 0xFF {
+		return nil, 0, fmt.Errorf("midi: DecodeEvent only supports meta events, got status %#x", b[n])
+	}
+	n++
+
+	if n >= len(b) {
+		return nil, 0, errors.New("midi: truncated meta event")
+	}
+	cmd := b[n]
+	n++
+
+	length, ln := DecodeVarint(b[n:])
+	n += ln
+	if n+int(length) > len(b) {
+		return nil, 0, errors.New("midi: truncated meta event payload")
+	}
+	payload := b[n : n+int(length)]
+	n += int(length)
+
+	ev := &Event{TimeDelta: deltaTime, MsgType: 0xF, Cmd: cmd}
+	if err := ev.decodeMetaPayload(payload); err != nil {
+		return nil, 0, err
+	}
+	return ev, n, nil
+}
+
+// decodeMetaPayload is the inverse of Event.encodeMetaPayload.
+func (e *Event) decodeMetaPayload(payload []byte) error {
+	switch e.Cmd {
+	case 0x00: // Sequence number
+		if len(payload) != 2 {
+			return fmt.Errorf("midi: sequence number payload must be 2 bytes, got %d", len(payload))
+		}
+		e.SeqNum = binary.BigEndian.Uint16(payload)
+	case 0x01: // Text event
+		e.Text = string(payload)
+	case 0x02: // Copyright
+		e.Copyright = string(payload)
+	case 0x03: // Sequence/Track name
+		e.SeqTrackName = string(payload)
+	case 0x04: // Instrument name
+		e.InstrumentName = string(payload)
+	case 0x05: // Lyric
+		e.Lyric = string(payload)
+	case 0x06: // Marker
+		e.Marker = string(payload)
+	case 0x07: // Cue point
+		e.CuePoint = string(payload)
+	case 0x20: // MIDI Channel Prefix
+		if len(payload) != 1 {
+			return fmt.Errorf("midi: channel prefix payload must be 1 byte, got %d", len(payload))
+		}
+		e.Channel = payload[0]
+	case 0x2f: // End of Track, no payload
+		if len(payload) != 0 {
+			return fmt.Errorf("midi: end of track payload must be empty, got %d bytes", len(payload))
+		}
+	case 0x51: // Tempo
+		if len(payload) != 3 {
+			return fmt.Errorf("midi: tempo payload must be 3 bytes, got %d", len(payload))
+		}
+		e.MsPerQuartNote = uint32(payload[0])<<16 | uint32(payload[1])<<8 | uint32(payload[2])
+	case 0x54: // SMPTE Offset
+		if len(payload) != 5 {
+			return fmt.Errorf("midi: SMPTE offset payload must be 5 bytes, got %d", len(payload))
+		}
+		e.SmpteOffset = &SmpteOffset{
+			Hour: payload[0], Minute: payload[1], Second: payload[2],
+			Frame: payload[3], FractionalFrame: payload[4],
+		}
+	case 0x58: // Time Signature
+		if len(payload) != 4 {
+			return fmt.Errorf("midi: time signature payload must be 4 bytes, got %d", len(payload))
+		}
+		e.TimeSignature = &TimeSignature{
+			Numerator: payload[0], Denominator: payload[1],
+			ClocksPerClick: payload[2], ThirtySecondsPerQuarter: payload[3],
+		}
+	case 0x59: // Key Signature
+		if len(payload) != 2 {
+			return fmt.Errorf("midi: key signature payload must be 2 bytes, got %d", len(payload))
+		}
+		e.Key = int32(int8(payload[0]))
+		e.Scale = uint32(payload[1])
+	case 0x7F: // Sequencer specific
+		e.SeqSpecificData = append([]byte(nil), payload...)
+	default:
+		return fmt.Errorf("midi: don't know how to decode meta cmd %#x", e.Cmd)
+	}
+	return nil
+}
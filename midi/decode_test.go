@@ -0,0 +1,62 @@
+package midi
+
+import "testing"
+
+// TestMetaEventRoundTrip parses then re-encodes one event per meta cmd in
+// the set Encode supports, and checks the re-encoded bytes exactly match
+// the bytes that were parsed. The repo has no SMF file fixtures to read
+// from disk, so the "real SMF" inputs here are full wire-format meta-event
+// byte sequences built the same way a real file's MTrk chunk would encode
+// them, rather than bytes read from an actual .mid file.
+func TestMetaEventRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		e    *Event
+	}{
+		{"SequenceNumber", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x00, SeqNum: 0x1234}},
+		{"Text", &Event{TimeDelta: 4, MsgType: 0xF, Cmd: 0x01, Text: "hello"}},
+		{"Copyright", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x02, Copyright: "(c) 2026"}},
+		{"SeqTrackName", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x03, SeqTrackName: "Piano"}},
+		{"InstrumentName", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x04, InstrumentName: "Grand Piano"}},
+		{"Lyric", &Event{TimeDelta: 120, MsgType: 0xF, Cmd: 0x05, Lyric: "la"}},
+		{"Marker", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x06, Marker: "Verse 1"}},
+		{"CuePoint", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x07, CuePoint: "cue"}},
+		{"ChannelPrefix", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x20, Channel: 3}},
+		{"EndOfTrack", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x2f}},
+		{"Tempo", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x51, MsPerQuartNote: 500000}},
+		{"SmpteOffset", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x54, SmpteOffset: &SmpteOffset{
+			Hour: 1, Minute: 2, Second: 3, Frame: 4, FractionalFrame: 5,
+		}}},
+		{"TimeSignature", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x58, TimeSignature: &TimeSignature{
+			Numerator: 3, Denominator: 2, ClocksPerClick: 24, ThirtySecondsPerQuarter: 8,
+		}}},
+		{"KeySignature", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x59, Key: -3, Scale: 1}},
+		{"SequencerSpecific", &Event{TimeDelta: 0, MsgType: 0xF, Cmd: 0x7F, SeqSpecificData: []byte{0xDE, 0xAD, 0xBE, 0xEF}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			wire := c.e.Encode()
+
+			decoded, n, err := DecodeEvent(wire)
+			if err != nil {
+				t.Fatalf("DecodeEvent: %v", err)
+			}
+			if n != len(wire) {
+				t.Fatalf("DecodeEvent consumed %d bytes, want %d", n, len(wire))
+			}
+
+			reencoded := decoded.Encode()
+			assertBytesEqual(t, reencoded, wire)
+		})
+	}
+}
+
+func TestDecodeEventRejectsNonMetaStatus(t *testing.T) {
+	// A Note On wire event: DecodeEvent should refuse it, not silently
+	// misparse it as a meta event.
+	wire := []byte{0x0, 0x90, 0x3C, 0x40}
+	if _, _, err := DecodeEvent(wire); err == nil {
+		t.Fatal("expected an error decoding a non-meta status byte")
+	}
+}
@@ -3,9 +3,7 @@ package midi
 import (
 	"bytes"
 	"encoding/binary"
-	"errors"
 	"fmt"
-	"log"
 )
 
 // http://www.midi.org/techspecs/midimessages.php
@@ -43,6 +41,7 @@ var metaCmdMap = map[byte]string{
 	0x20: "MIDI Channel Prefix",
 	0x2f: "End of Track",
 	0x51: "Tempo",
+	0x54: "SMPTE Offset",
 	0x58: "Time Signature",
 	0x59: "Key Signature",
 	0x7F: "Sequencer specific",
@@ -53,24 +52,25 @@ var metaCmdMap = map[byte]string{
 }
 
 var metaByteMap = map[string]byte{
-	"Sequence number":                          0x0,
-	"Text event":                               0x01,
-	"Copyright":                                0x02,
-	"Sequence/Track name":                      0x03,
-	"Instrument name":                          0x04,
-	"Lyric":                                    0x05,
-	"Marker":                                   0x06,
-	"Cue Point":                                0x07,
-	"MIDI Channel Prefix":                      0x20,
-	"End of Track":                             0x2f,
-	"Tempo":                                    0x51,
-	"Time Signature":                           0x58,
-	"Key Signature":                            0x59,
-	"Sequencer specific":                       0x7F,
-	"Timing Clock":                             0x8F,
-	"Start current sequence":                   0xFA,
+	"Sequence number":        0x0,
+	"Text event":             0x01,
+	"Copyright":              0x02,
+	"Sequence/Track name":    0x03,
+	"Instrument name":        0x04,
+	"Lyric":                  0x05,
+	"Marker":                 0x06,
+	"Cue Point":              0x07,
+	"MIDI Channel Prefix":    0x20,
+	"End of Track":           0x2f,
+	"Tempo":                  0x51,
+	"SMPTE Offset":           0x54,
+	"Time Signature":         0x58,
+	"Key Signature":          0x59,
+	"Sequencer specific":     0x7F,
+	"Timing Clock":           0x8F,
+	"Start current sequence": 0xFA,
 	"Continue stopped sequence where left off": 0xFB,
-	"Stop sequence":                            0xFC,
+	"Stop sequence": 0xFC,
 }
 
 // Event
@@ -96,6 +96,10 @@ type Event struct {
 	Channel      uint8
 	AbsPitchBend uint16
 	RelPitchBend int16
+	// Live-wire System Common / System Real-Time fields, used by StreamParser.
+	SongPosition uint16 // valid when MsgType == MsgTypeSongPosition
+	SysExData    []byte // valid when MsgType == MsgTypeSysEx
+	IsRealtime   bool   // true for System Real-Time messages (timing clock, start, stop, ...)
 	// Meta
 	Cmd            uint8
 	SeqNum         uint16
@@ -115,6 +119,8 @@ type Event struct {
 	Scale uint32 // 0 or 1
 	//
 	SmpteOffset *SmpteOffset
+	// SeqSpecificData holds the raw payload of a Sequencer-specific meta-event (cmd 0x7F).
+	SeqSpecificData []byte
 }
 
 // String implements the stringer interface
@@ -134,6 +140,9 @@ func (e *Event) String() string {
 	if e.MsgType == eventByteMap["NoteOn"] {
 		out += fmt.Sprintf(" Note: %s", MidiNoteToName(int(e.Note)))
 	}
+	if e.MsgType == eventByteMap["ControlChange"] {
+		out += fmt.Sprintf(" %s: %d", ControllerName(e.Controller), e.NewValue)
+	}
 	if e.Cmd != 0 {
 		out = fmt.Sprintf("Ch %d @ %d \t%s", e.MsgChan, e.TimeDelta, metaCmdMap[e.Cmd])
 		switch e.Cmd {
@@ -154,6 +163,10 @@ func (e *Event) Encode() []byte {
 
 	// msg type and chan are stored together
 	msgData := []byte{(e.MsgType << 4) | e.MsgChan}
+	// Meta events don't carry a channel nibble: the status byte is always 0xFF.
+	if e.MsgType == 0xF {
+		msgData = []byte{0xFF}
+	}
 	//fmt.Println(e.MsgChan)
 	//fmt.Printf("%X\n", (msgData[0]&0xF0)>>4)
 	buff.Write(msgData)
@@ -210,8 +223,8 @@ func (e *Event) Encode() []byte {
 					This message sent when the patch number changes. Value is the new program number.
 		*/
 	case 0xC:
+		// Program Change carries a single data byte: the new program number.
 		binary.Write(buff, binary.BigEndian, e.NewProgram)
-		binary.Write(buff, binary.BigEndian, e.NewValue)
 		// Channel Pressure (Aftertouch)
 		// This message is most often sent by pressing down on the key after it "bottoms out".
 		// This message is different from polyphonic after-touch.
@@ -238,7 +251,10 @@ func (e *Event) Encode() []byte {
 		// All meta-events start with FF followed by the command (xx), the length,
 		// or number of bytes that will contain data (nn), and the actual data (dd).
 	case 0xF:
-		// TODO
+		buff.WriteByte(e.Cmd)
+		payload := e.encodeMetaPayload()
+		buff.Write(EncodeVarint(uint32(len(payload))))
+		buff.Write(payload)
 	default:
 		fmt.Printf("didn't encode %#v because didn't know how to\n", e)
 	}
@@ -246,6 +262,69 @@ func (e *Event) Encode() []byte {
 	return buff.Bytes()
 }
 
+// encodeMetaPayload encodes the data bytes (dd) of a meta-event, i.e.
+// everything after the FF <cmd> <len> prefix written by Encode.
+func (e *Event) encodeMetaPayload() []byte {
+	buff := bytes.NewBuffer(nil)
+	switch e.Cmd {
+	case 0x00: // Sequence number
+		binary.Write(buff, binary.BigEndian, e.SeqNum)
+	case 0x01: // Text event
+		buff.WriteString(e.Text)
+	case 0x02: // Copyright
+		buff.WriteString(e.Copyright)
+	case 0x03: // Sequence/Track name
+		buff.WriteString(e.SeqTrackName)
+	case 0x04: // Instrument name
+		buff.WriteString(e.InstrumentName)
+	case 0x05: // Lyric
+		buff.WriteString(e.Lyric)
+	case 0x06: // Marker
+		buff.WriteString(e.Marker)
+	case 0x07: // Cue point
+		buff.WriteString(e.CuePoint)
+	case 0x20: // MIDI Channel Prefix
+		buff.WriteByte(e.Channel)
+	case 0x2f: // End of Track, no payload
+	case 0x51: // Tempo, 3-byte microseconds per quarter note
+		us := e.microsecondsPerQuarter()
+		buff.Write([]byte{byte(us >> 16), byte(us >> 8), byte(us)})
+	case 0x54: // SMPTE Offset
+		if e.SmpteOffset != nil {
+			buff.Write([]byte{
+				e.SmpteOffset.Hour, e.SmpteOffset.Minute, e.SmpteOffset.Second,
+				e.SmpteOffset.Frame, e.SmpteOffset.FractionalFrame,
+			})
+		}
+	case 0x58: // Time Signature, nn dd cc bb
+		if e.TimeSignature != nil {
+			buff.Write([]byte{
+				e.TimeSignature.Numerator, e.TimeSignature.Denominator,
+				e.TimeSignature.ClocksPerClick, e.TimeSignature.ThirtySecondsPerQuarter,
+			})
+		}
+	case 0x59: // Key Signature, sf mi
+		buff.Write([]byte{byte(int8(e.Key)), byte(e.Scale)})
+	case 0x7F: // Sequencer specific
+		buff.Write(e.SeqSpecificData)
+	default:
+		fmt.Printf("didn't encode meta cmd %#X because didn't know how to\n", e.Cmd)
+	}
+	return buff.Bytes()
+}
+
+// microsecondsPerQuarter returns the Tempo meta-event payload value, preferring
+// an explicit MsPerQuartNote and falling back to deriving it from Bpm.
+func (e *Event) microsecondsPerQuarter() uint32 {
+	if e.MsPerQuartNote != 0 {
+		return e.MsPerQuartNote
+	}
+	if e.Bpm != 0 {
+		return 60000000 / e.Bpm
+	}
+	return 500000 // default tempo, 120 BPM
+}
+
 // Size represents the byte size to encode the event
 func (e *Event) Size() uint32 {
 	switch e.MsgType {
@@ -255,9 +334,9 @@ func (e *Event) Size() uint32 {
 	case 0x8, 0x9, 0xA, 0xB, 0xE:
 		return 2
 	case 0xF:
-		// meta event
-		// NOT currently support, blowing up on purpose
-		log.Fatal(errors.New("Can't encode meta events, not supported yet"))
+		// meta event: cmd byte + varint-encoded length + payload
+		payload := e.encodeMetaPayload()
+		return uint32(1 + len(EncodeVarint(uint32(len(payload)))) + len(payload))
 	}
 	return 0
 }
@@ -0,0 +1,108 @@
+package midi
+
+import "testing"
+
+func TestEncodeMetaEndOfTrack(t *testing.T) {
+	e := &Event{MsgType: 0xF, Cmd: 0x2f}
+	got := e.Encode()
+	want := []byte{0x0, 0xFF, 0x2f, 0x0}
+	assertBytesEqual(t, got, want)
+	assertSizeMatchesEncode(t, e)
+}
+
+func TestEncodeMetaTempoFromMsPerQuartNote(t *testing.T) {
+	e := &Event{MsgType: 0xF, Cmd: 0x51, MsPerQuartNote: 500000}
+	got := e.Encode()
+	want := []byte{0x0, 0xFF, 0x51, 0x3, 0x07, 0xA1, 0x20}
+	assertBytesEqual(t, got, want)
+	assertSizeMatchesEncode(t, e)
+}
+
+func TestEncodeMetaTempoFromBpm(t *testing.T) {
+	e := &Event{MsgType: 0xF, Cmd: 0x51, Bpm: 120}
+	got := e.Encode()
+	want := []byte{0x0, 0xFF, 0x51, 0x3, 0x07, 0xA1, 0x20}
+	assertBytesEqual(t, got, want)
+}
+
+func TestEncodeMetaTimeSignature(t *testing.T) {
+	e := &Event{MsgType: 0xF, Cmd: 0x58, TimeSignature: &TimeSignature{
+		Numerator: 4, Denominator: 2, ClocksPerClick: 24, ThirtySecondsPerQuarter: 8,
+	}}
+	got := e.Encode()
+	want := []byte{0x0, 0xFF, 0x58, 0x4, 0x4, 0x2, 0x18, 0x8}
+	assertBytesEqual(t, got, want)
+	assertSizeMatchesEncode(t, e)
+}
+
+func TestEncodeMetaKeySignature(t *testing.T) {
+	// 2 sharps, major key
+	e := &Event{MsgType: 0xF, Cmd: 0x59, Key: 2, Scale: 0}
+	got := e.Encode()
+	want := []byte{0x0, 0xFF, 0x59, 0x2, 0x2, 0x0}
+	assertBytesEqual(t, got, want)
+
+	// 3 flats, minor key
+	e = &Event{MsgType: 0xF, Cmd: 0x59, Key: -3, Scale: 1}
+	got = e.Encode()
+	want = []byte{0x0, 0xFF, 0x59, 0x2, 0xFD, 0x1}
+	assertBytesEqual(t, got, want)
+}
+
+func TestEncodeMetaSeqTrackName(t *testing.T) {
+	e := &Event{MsgType: 0xF, Cmd: 0x03, SeqTrackName: "Piano"}
+	got := e.Encode()
+	want := append([]byte{0x0, 0xFF, 0x03, 0x5}, []byte("Piano")...)
+	assertBytesEqual(t, got, want)
+	assertSizeMatchesEncode(t, e)
+}
+
+func TestEncodeMetaSmpteOffset(t *testing.T) {
+	e := &Event{MsgType: 0xF, Cmd: 0x54, SmpteOffset: &SmpteOffset{
+		Hour: 1, Minute: 2, Second: 3, Frame: 4, FractionalFrame: 5,
+	}}
+	got := e.Encode()
+	want := []byte{0x0, 0xFF, 0x54, 0x5, 0x1, 0x2, 0x3, 0x4, 0x5}
+	assertBytesEqual(t, got, want)
+	assertSizeMatchesEncode(t, e)
+}
+
+func TestEncodeMetaChannelPrefix(t *testing.T) {
+	e := &Event{MsgType: 0xF, Cmd: 0x20, Channel: 3}
+	got := e.Encode()
+	want := []byte{0x0, 0xFF, 0x20, 0x1, 0x3}
+	assertBytesEqual(t, got, want)
+}
+
+func TestEncodeMetaSequencerSpecific(t *testing.T) {
+	e := &Event{MsgType: 0xF, Cmd: 0x7F, SeqSpecificData: []byte{0xDE, 0xAD, 0xBE, 0xEF}}
+	got := e.Encode()
+	want := []byte{0x0, 0xFF, 0x7F, 0x4, 0xDE, 0xAD, 0xBE, 0xEF}
+	assertBytesEqual(t, got, want)
+	assertSizeMatchesEncode(t, e)
+}
+
+func assertBytesEqual(t *testing.T, got, want []byte) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d bytes %#v, want %d bytes %#v", len(got), got, len(want), want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("byte %d: got %#x, want %#x (full got=%#v want=%#v)", i, got[i], want[i], got, want)
+		}
+	}
+}
+
+// assertSizeMatchesEncode checks that Size() reports the cmd+len+payload
+// portion of Encode's output, i.e. everything after the delta-time varint
+// and the 0xFF status byte.
+func assertSizeMatchesEncode(t *testing.T, e *Event) {
+	t.Helper()
+	encoded := e.Encode()
+	deltaLen := len(EncodeVarint(e.TimeDelta))
+	wantSize := uint32(len(encoded) - deltaLen - 1)
+	if got := e.Size(); got != wantSize {
+		t.Fatalf("Size() = %d, want %d", got, wantSize)
+	}
+}
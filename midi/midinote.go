@@ -0,0 +1,15 @@
+package midi
+
+import "fmt"
+
+var noteNames = [12]string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+
+// MidiNoteToName converts a MIDI note number (0-127) into scientific pitch
+// notation, e.g. 60 -> "C4".
+func MidiNoteToName(note int) string {
+	if note < 0 || note > 127 {
+		return fmt.Sprintf("?(%d)", note)
+	}
+	octave := note/12 - 1
+	return fmt.Sprintf("%s%d", noteNames[note%12], octave)
+}
@@ -0,0 +1,154 @@
+package midi
+
+import (
+	"container/heap"
+	"time"
+)
+
+// Track is the ordered sequence of events stored in a single MTrk chunk.
+type Track []*Event
+
+// Division describes how tick values map to real time, as stored in the SMF
+// header chunk: either pulses-per-quarter-note (PPQ), or SMPTE frames.
+type Division struct {
+	// PPQ is the number of ticks per quarter note. Zero if the file uses SMPTE timing.
+	PPQ uint16
+	// SmpteFormat is the SMPTE frame rate (24, 25, 29 or 30), zero if PPQ timing is used.
+	SmpteFormat uint8
+	// TicksPerFrame is the number of ticks per SMPTE frame, used only when SmpteFormat != 0.
+	TicksPerFrame uint8
+}
+
+// defaultTempo is the tempo (microseconds per quarter note) assumed until a
+// Tempo meta-event is seen, per the SMF spec (120 BPM).
+const defaultTempo = 500000
+
+// trackCursor tracks one track's position within the merge: events[pos] is
+// the next event to emit, at absolute tick absTick.
+type trackCursor struct {
+	trackIdx int
+	events   Track
+	pos      int
+	absTick  uint64
+}
+
+type trackHeap []*trackCursor
+
+func (h trackHeap) Len() int { return len(h) }
+func (h trackHeap) Less(i, j int) bool {
+	if h[i].absTick == h[j].absTick {
+		return h[i].trackIdx < h[j].trackIdx
+	}
+	return h[i].absTick < h[j].absTick
+}
+func (h trackHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *trackHeap) Push(x interface{}) {
+	*h = append(*h, x.(*trackCursor))
+}
+func (h *trackHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// Sequencer merges the tracks of a parsed format-1 SMF into a single
+// pull-based, absolute-tick-ordered iterator. It walks the given tracks in
+// place and never builds a merged copy of their events. It's suitable for real-time playback or for
+// re-serializing a format-1 file as format 0.
+type Sequencer struct {
+	cursors         trackHeap
+	division        Division
+	tempoMapEnabled bool
+	curTempo        uint32
+	lastAbsTick     uint64
+	elapsed         time.Duration
+}
+
+// SequencerOption configures a Sequencer at construction time.
+type SequencerOption func(*Sequencer)
+
+// WithTempoMap enables wall-clock tracking: Elapsed will report the time of
+// the most recently returned event, computed from division and any Tempo
+// meta-events encountered so far in the merged stream.
+func WithTempoMap(division Division) SequencerOption {
+	return func(s *Sequencer) {
+		s.division = division
+		s.tempoMapEnabled = true
+	}
+}
+
+// NewSequencer builds a Sequencer over tracks, which should be the parsed
+// MTrk chunks of a format-1 SMF in file order.
+func NewSequencer(tracks []Track, opts ...SequencerOption) *Sequencer {
+	s := &Sequencer{curTempo: defaultTempo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	for i, tr := range tracks {
+		if len(tr) == 0 {
+			continue
+		}
+		heap.Push(&s.cursors, &trackCursor{
+			trackIdx: i,
+			events:   tr,
+			pos:      0,
+			absTick:  uint64(tr[0].TimeDelta),
+		})
+	}
+	return s
+}
+
+// Next pops the earliest not-yet-emitted event across all tracks, advances
+// that track's cursor, and pushes its next event back into the merge. ok is
+// false once every track is exhausted.
+func (s *Sequencer) Next() (trackIdx int, absTick uint64, ev *Event, ok bool) {
+	if s.cursors.Len() == 0 {
+		return 0, 0, nil, false
+	}
+
+	c := heap.Pop(&s.cursors).(*trackCursor)
+	trackIdx, absTick, ev = c.trackIdx, c.absTick, c.events[c.pos]
+
+	if s.tempoMapEnabled {
+		s.advanceClock(absTick)
+		if ev.MsgType == 0xF && ev.Cmd == 0x51 {
+			s.curTempo = ev.microsecondsPerQuarter()
+		}
+	}
+
+	c.pos++
+	if c.pos < len(c.events) {
+		c.absTick += uint64(c.events[c.pos].TimeDelta)
+		heap.Push(&s.cursors, c)
+	}
+
+	return trackIdx, absTick, ev, true
+}
+
+// Elapsed returns the wall-clock time of the most recently returned event.
+// It only advances when the Sequencer was built with WithTempoMap.
+func (s *Sequencer) Elapsed() time.Duration {
+	return s.elapsed
+}
+
+// advanceClock accumulates elapsed wall-clock time for the ticks between the
+// last emitted event and absTick, using the tempo in effect up to now.
+func (s *Sequencer) advanceClock(absTick uint64) {
+	delta := absTick - s.lastAbsTick
+	s.lastAbsTick = absTick
+	if delta == 0 {
+		return
+	}
+
+	if s.division.SmpteFormat != 0 {
+		// SMPTE timing: a tick is a fixed fraction of a second, tempo doesn't apply.
+		ticksPerSecond := float64(s.division.SmpteFormat) * float64(s.division.TicksPerFrame)
+		s.elapsed += time.Duration(float64(delta) / ticksPerSecond * float64(time.Second))
+		return
+	}
+
+	nsPerTick := float64(s.curTempo) * float64(time.Microsecond) / float64(s.division.PPQ)
+	s.elapsed += time.Duration(float64(delta) * nsPerTick)
+}
@@ -0,0 +1,63 @@
+package midi
+
+import "testing"
+
+func TestSequencerInterleavesTracksInTickOrder(t *testing.T) {
+	// Track 0: ticks 0, 10, 30
+	tr0 := Track{
+		{TimeDelta: 0, MsgType: 0x9, Note: 60},
+		{TimeDelta: 10, MsgType: 0x8, Note: 60},
+		{TimeDelta: 20, MsgType: 0x9, Note: 62},
+	}
+	// Track 1: ticks 5, 15
+	tr1 := Track{
+		{TimeDelta: 5, MsgType: 0x9, Note: 67},
+		{TimeDelta: 10, MsgType: 0x8, Note: 67},
+	}
+
+	seq := NewSequencer([]Track{tr0, tr1})
+
+	wantTrack := []int{0, 1, 0, 1, 0}
+	wantTick := []uint64{0, 5, 10, 15, 30}
+
+	for i := range wantTrack {
+		trackIdx, absTick, ev, ok := seq.Next()
+		if !ok {
+			t.Fatalf("event %d: Next() reported exhausted too early", i)
+		}
+		if trackIdx != wantTrack[i] || absTick != wantTick[i] {
+			t.Fatalf("event %d: got (track=%d, tick=%d), want (track=%d, tick=%d)",
+				i, trackIdx, absTick, wantTrack[i], wantTick[i])
+		}
+		if ev == nil {
+			t.Fatalf("event %d: got nil event", i)
+		}
+	}
+
+	if _, _, _, ok := seq.Next(); ok {
+		t.Fatal("Next() should report exhausted once all tracks are drained")
+	}
+}
+
+func TestSequencerTempoMapTracksElapsedTime(t *testing.T) {
+	// 480 PPQ, 120 BPM (500000us/quarter) => 1 tick == 500000/480 us.
+	tr := Track{
+		{TimeDelta: 0, MsgType: 0xF, Cmd: 0x51, MsPerQuartNote: 500000},
+		{TimeDelta: 480, MsgType: 0x9, Note: 60},
+	}
+
+	seq := NewSequencer([]Track{tr}, WithTempoMap(Division{PPQ: 480}))
+
+	if _, _, _, ok := seq.Next(); !ok {
+		t.Fatal("expected tempo event")
+	}
+	if _, _, _, ok := seq.Next(); !ok {
+		t.Fatal("expected note-on event")
+	}
+
+	got := seq.Elapsed()
+	want := 500 // milliseconds
+	if ms := got.Milliseconds(); ms != int64(want) {
+		t.Fatalf("Elapsed() = %v, want %dms", got, want)
+	}
+}
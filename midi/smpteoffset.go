@@ -0,0 +1,11 @@
+package midi
+
+// SmpteOffset is the payload of a SMPTE Offset meta-event (cmd 0x54),
+// specifying the SMPTE time at which a track should start.
+type SmpteOffset struct {
+	Hour            uint8
+	Minute          uint8
+	Second          uint8
+	Frame           uint8
+	FractionalFrame uint8
+}
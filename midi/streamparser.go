@@ -0,0 +1,201 @@
+package midi
+
+import "io"
+
+// System Common and System Real-Time status bytes, used by StreamParser for
+// live MIDI wire data. Unlike the 0x8-0xF MsgType values used for SMF channel
+// and meta events (which store only the high nibble), these occupy the full
+// status byte since System messages carry no channel nibble.
+const (
+	MsgTypeSysEx         uint8 = 0xF0 // SysEx start
+	MsgTypeSongPosition  uint8 = 0xF2
+	MsgTypeSongSelect    uint8 = 0xF3
+	MsgTypeTuneRequest   uint8 = 0xF6
+	MsgTypeSysExEnd      uint8 = 0xF7 // SysEx end, or a continuation packet extending one
+	MsgTypeTimingClock   uint8 = 0xF8
+	MsgTypeStart         uint8 = 0xFA
+	MsgTypeContinue      uint8 = 0xFB
+	MsgTypeStop          uint8 = 0xFC
+	MsgTypeActiveSensing uint8 = 0xFE
+	MsgTypeReset         uint8 = 0xFF
+)
+
+// StreamParser incrementally decodes a live MIDI byte stream (as opposed to
+// an SMF file) into Events, handling running status, System Common messages,
+// System Real-Time messages interleaved inside other messages, and
+// multi-packet SysEx.
+type StreamParser struct {
+	// OnEvent is called with each decoded Event, in wire order.
+	OnEvent func(*Event)
+
+	runningStatus  byte   // last channel-message status byte seen, 0 if none yet
+	status         byte   // status byte of the message currently being assembled, 0 if idle
+	data           []byte // data bytes collected so far for status
+	sysEx          []byte // in-progress SysEx payload, nil when not inside a SysEx
+	sysExContinues bool   // set by ContinueSysEx: the next MsgTypeSysExEnd byte is a continuation marker, not a terminator
+}
+
+// NewStreamParser creates a StreamParser that invokes onEvent for each Event
+// decoded from the fed byte stream.
+func NewStreamParser(onEvent func(*Event)) *StreamParser {
+	return &StreamParser{OnEvent: onEvent}
+}
+
+// ContinueSysEx signals that the SysEx currently open (started by a
+// preceding MsgTypeSysEx status byte) is split across multiple transport
+// packets: the next MsgTypeSysExEnd (0xF7) byte fed extends the SysEx
+// instead of terminating it. A flat byte stream can't tell a terminating
+// 0xF7 apart from a continuation one on its own; callers that reconstruct a
+// SysEx from packets whose own framing says more data follows (e.g. an SMF
+// continuation packet whose data doesn't itself end in 0xF7) must call this
+// before feeding that packet's leading 0xF7.
+func (p *StreamParser) ContinueSysEx() {
+	p.sysExContinues = true
+}
+
+// Parse feeds every byte read from r through the parser until r is
+// exhausted or returns an error.
+func (p *StreamParser) Parse(r io.Reader) error {
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		for _, b := range buf[:n] {
+			p.Feed(b)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Feed processes a single incoming byte, emitting zero or one Events via
+// OnEvent. System Real-Time bytes may arrive in the middle of any other
+// message without disturbing it.
+func (p *StreamParser) Feed(b byte) {
+	switch {
+	case isRealtimeStatus(b):
+		// Real-time bytes are injected into the stream and never affect
+		// running status or whatever message is currently being assembled.
+		p.emit(&Event{MsgType: b, IsRealtime: true})
+
+	case b == MsgTypeSysEx:
+		// System Common messages, including SysEx, cancel running status.
+		p.runningStatus = 0
+		p.sysEx = []byte{}
+		p.status, p.data = 0, nil
+
+	case b == MsgTypeSysExEnd && p.sysEx != nil:
+		p.runningStatus = 0
+		if p.sysExContinues {
+			// This 0xF7 is a continuation marker, not the terminator: the
+			// SysEx stays open and its data keeps accumulating.
+			p.sysExContinues = false
+			return
+		}
+		p.emit(&Event{MsgType: MsgTypeSysEx, SysExData: p.sysEx})
+		p.sysEx = nil
+
+	case b == MsgTypeSysExEnd:
+		// A continuation packet starting cold: extends a SysEx across
+		// multiple packets without a preceding 0xF0 of its own.
+		p.runningStatus = 0
+		p.sysEx = []byte{}
+
+	case b >= 0x80:
+		if b <= 0xEF {
+			p.runningStatus = b
+		} else {
+			// Other System Common messages also cancel running status.
+			p.runningStatus = 0
+		}
+		p.status = b
+		p.data = p.data[:0]
+		if dataBytesFor(b) == 0 {
+			p.completeMessage()
+		}
+
+	default: // data byte
+		if p.sysEx != nil {
+			p.sysEx = append(p.sysEx, b)
+			return
+		}
+		if p.status == 0 {
+			if p.runningStatus == 0 {
+				return // no status byte to interpret this against yet
+			}
+			p.status = p.runningStatus
+			p.data = p.data[:0]
+		}
+		p.data = append(p.data, b)
+		if len(p.data) >= dataBytesFor(p.status) {
+			p.completeMessage()
+		}
+	}
+}
+
+// completeMessage builds an Event from the current status byte and
+// collected data bytes, emits it, and resets the in-progress message
+// (running status itself is left untouched).
+func (p *StreamParser) completeMessage() {
+	ev := &Event{MsgType: p.status >> 4, MsgChan: p.status & 0x0F}
+	switch p.status & 0xF0 {
+	case 0x80, 0x90, 0xA0:
+		ev.Note, ev.Velocity = p.data[0], p.data[1]
+	case 0xB0:
+		ev.Controller, ev.NewValue = p.data[0], p.data[1]
+	case 0xC0:
+		ev.NewProgram = p.data[0]
+	case 0xD0:
+		ev.Pressure = p.data[0]
+	case 0xE0:
+		ev.AbsPitchBend = uint16(p.data[1])<<7 | uint16(p.data[0])
+		ev.RelPitchBend = int16(ev.AbsPitchBend) - 0x2000
+	case 0xF0:
+		ev.MsgType = p.status
+		switch p.status {
+		case MsgTypeSongPosition:
+			ev.SongPosition = uint16(p.data[1])<<7 | uint16(p.data[0])
+		case MsgTypeSongSelect:
+			ev.NewValue = p.data[0]
+		}
+	}
+
+	p.status, p.data = 0, nil
+	p.emit(ev)
+}
+
+func (p *StreamParser) emit(ev *Event) {
+	if p.OnEvent != nil {
+		p.OnEvent(ev)
+	}
+}
+
+// dataBytesFor returns how many data bytes follow a given status byte.
+func dataBytesFor(status byte) int {
+	switch status & 0xF0 {
+	case 0x80, 0x90, 0xA0, 0xB0, 0xE0:
+		return 2
+	case 0xC0, 0xD0:
+		return 1
+	}
+	switch status {
+	case MsgTypeSongPosition:
+		return 2
+	case MsgTypeSongSelect:
+		return 1
+	}
+	return 0
+}
+
+// isRealtimeStatus reports whether b is a System Real-Time status byte,
+// which may legally interrupt any other message.
+func isRealtimeStatus(b byte) bool {
+	switch b {
+	case MsgTypeTimingClock, MsgTypeStart, MsgTypeContinue, MsgTypeStop, MsgTypeActiveSensing, MsgTypeReset:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package midi
+
+import "testing"
+
+func TestStreamParserRunningStatus(t *testing.T) {
+	var got []*Event
+	p := NewStreamParser(func(e *Event) { got = append(got, e) })
+
+	// Note On ch0, then a second Note On reusing running status (no new status byte).
+	for _, b := range []byte{0x90, 0x3C, 0x64, 0x3E, 0x50} {
+		p.Feed(b)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2: %#v", len(got), got)
+	}
+	if got[0].Note != 0x3C || got[0].Velocity != 0x64 {
+		t.Fatalf("event 0 = %#v, want note 0x3C vel 0x64", got[0])
+	}
+	if got[1].Note != 0x3E || got[1].Velocity != 0x50 {
+		t.Fatalf("event 1 = %#v, want note 0x3E vel 0x50", got[1])
+	}
+}
+
+func TestStreamParserRealtimeInterleaved(t *testing.T) {
+	var got []*Event
+	p := NewStreamParser(func(e *Event) { got = append(got, e) })
+
+	// Timing Clock arrives in the middle of a Note On, must not break it.
+	for _, b := range []byte{0x90, 0x3C, MsgTypeTimingClock, 0x64} {
+		p.Feed(b)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2: %#v", len(got), got)
+	}
+	if !got[0].IsRealtime || got[0].MsgType != MsgTypeTimingClock {
+		t.Fatalf("event 0 = %#v, want realtime timing clock", got[0])
+	}
+	if got[1].Note != 0x3C || got[1].Velocity != 0x64 {
+		t.Fatalf("event 1 = %#v, want note 0x3C vel 0x64", got[1])
+	}
+}
+
+func TestStreamParserSysEx(t *testing.T) {
+	var got []*Event
+	p := NewStreamParser(func(e *Event) { got = append(got, e) })
+
+	for _, b := range []byte{0xF0, 0x7E, 0x00, 0xF7} {
+		p.Feed(b)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("got %d events, want 1: %#v", len(got), got)
+	}
+	want := []byte{0x7E, 0x00}
+	if len(got[0].SysExData) != len(want) || got[0].SysExData[0] != want[0] || got[0].SysExData[1] != want[1] {
+		t.Fatalf("SysExData = %#v, want %#v", got[0].SysExData, want)
+	}
+}
+
+func TestStreamParserSysExContinuationPacket(t *testing.T) {
+	var got []*Event
+	p := NewStreamParser(func(e *Event) { got = append(got, e) })
+
+	p.Feed(MsgTypeSysEx) // F0: open the SysEx
+	p.Feed(0x7E)
+	p.ContinueSysEx()
+	p.Feed(MsgTypeSysExEnd) // continuation marker, swallowed, SysEx stays open
+	p.Feed(0x00)
+	p.Feed(MsgTypeSysExEnd) // real terminator
+
+	if len(got) != 1 {
+		t.Fatalf("got %d events, want 1: %#v", len(got), got)
+	}
+	want := []byte{0x7E, 0x00}
+	if len(got[0].SysExData) != len(want) || got[0].SysExData[0] != want[0] || got[0].SysExData[1] != want[1] {
+		t.Fatalf("SysExData = %#v, want %#v (concatenation of both packets)", got[0].SysExData, want)
+	}
+}
+
+func TestStreamParserSysExCancelsRunningStatus(t *testing.T) {
+	var got []*Event
+	p := NewStreamParser(func(e *Event) { got = append(got, e) })
+
+	// Note On, then a complete SysEx, then two bare data bytes that must NOT
+	// be reinterpreted as another Note On via the stale running status.
+	for _, b := range []byte{0x90, 0x3C, 0x40, 0xF0, 0x7E, 0x00, 0xF7, 0x3E, 0x50} {
+		p.Feed(b)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2 (Note On + SysEx): %#v", len(got), got)
+	}
+	if got[0].MsgType != 0x9 || got[0].Note != 0x3C {
+		t.Fatalf("event 0 = %#v, want the Note On", got[0])
+	}
+	if got[1].MsgType != MsgTypeSysEx {
+		t.Fatalf("event 1 = %#v, want the SysEx", got[1])
+	}
+}
+
+func TestStreamParserSongPosition(t *testing.T) {
+	var got []*Event
+	p := NewStreamParser(func(e *Event) { got = append(got, e) })
+
+	for _, b := range []byte{MsgTypeSongPosition, 0x00, 0x01} {
+		p.Feed(b)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("got %d events, want 1: %#v", len(got), got)
+	}
+	if got[0].SongPosition != 0x80 {
+		t.Fatalf("SongPosition = %d, want %d", got[0].SongPosition, 0x80)
+	}
+}
@@ -0,0 +1,20 @@
+package midi
+
+import "fmt"
+
+// TimeSignature is the payload of a Time Signature meta-event, stored in the
+// SMF "nn dd cc bb" layout.
+type TimeSignature struct {
+	Numerator               uint8 // nn
+	Denominator             uint8 // dd, denominator expressed as a negative power of two (2 => quarter note)
+	ClocksPerClick          uint8 // cc, number of MIDI clocks per metronome click
+	ThirtySecondsPerQuarter uint8 // bb, number of 1/32 notes per 24 MIDI clocks
+}
+
+// String implements the stringer interface
+func (t *TimeSignature) String() string {
+	if t == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", t.Numerator, 1<<t.Denominator)
+}
@@ -0,0 +1,28 @@
+package midi
+
+// EncodeVarint encodes v as a MIDI variable-length quantity (VLQ), the
+// encoding used for delta-times and for meta-event payload lengths.
+func EncodeVarint(v uint32) []byte {
+	buf := []byte{byte(v & 0x7F)}
+	v >>= 7
+	for v > 0 {
+		buf = append([]byte{byte(v&0x7F) | 0x80}, buf...)
+		v >>= 7
+	}
+	return buf
+}
+
+// DecodeVarint reads a MIDI variable-length quantity from the start of b,
+// returning the decoded value and the number of bytes it consumed.
+func DecodeVarint(b []byte) (uint32, int) {
+	var v uint32
+	var n int
+	for _, c := range b {
+		n++
+		v = (v << 7) | uint32(c&0x7F)
+		if c&0x80 == 0 {
+			break
+		}
+	}
+	return v, n
+}